@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/BurntSushi/toml"
+)
+
+const (
+	repoConfigFileName   = ".phantom.toml"
+	globalConfigFileName = "phantom.toml"
+	defaultWorktreeDir   = "{{.Repo}}/.git/phantom/{{.Name}}"
+)
+
+// Config holds per-repository phantom settings loaded from .phantom.toml (or
+// a global phantom.toml under $XDG_CONFIG_HOME/phantom).
+type Config struct {
+	WorktreeDir         string   `toml:"worktreeDir"`
+	BaseBranch          string   `toml:"baseBranch"`
+	DefaultBranchPrefix string   `toml:"defaultBranchPrefix"`
+	CopyFiles           []string `toml:"copyFiles"`
+	PostCreate          []string `toml:"postCreate"`
+}
+
+func defaultConfig() Config {
+	return Config{WorktreeDir: defaultWorktreeDir}
+}
+
+// loadConfig reads phantom configuration for the repository rooted at
+// repoRoot. It prefers a repo-local .phantom.toml, falls back to a global
+// phantom.toml under $XDG_CONFIG_HOME/phantom, and otherwise returns
+// defaultConfig.
+func loadConfig(repoRoot string) (Config, error) {
+	if path := filepath.Join(repoRoot, repoConfigFileName); fileExists(path) {
+		return decodeConfig(path)
+	}
+
+	if path, ok := globalConfigPath(); ok && fileExists(path) {
+		return decodeConfig(path)
+	}
+
+	return defaultConfig(), nil
+}
+
+func globalConfigPath() (string, bool) {
+	xdgConfigHome := os.Getenv("XDG_CONFIG_HOME")
+	if xdgConfigHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", false
+		}
+		xdgConfigHome = filepath.Join(home, ".config")
+	}
+	return filepath.Join(xdgConfigHome, "phantom", globalConfigFileName), true
+}
+
+func decodeConfig(path string) (Config, error) {
+	cfg := defaultConfig()
+	if _, err := toml.DecodeFile(path, &cfg); err != nil {
+		return Config{}, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if cfg.WorktreeDir == "" {
+		cfg.WorktreeDir = defaultWorktreeDir
+	}
+	return cfg, nil
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// worktreeDirData is the template data available to a worktreeDir pattern.
+type worktreeDirData struct {
+	Repo   string
+	Name   string
+	Branch string
+}
+
+// renderWorktreeDir expands a worktreeDir template against data and returns
+// a cleaned absolute path.
+func renderWorktreeDir(pattern string, data worktreeDirData) (string, error) {
+	tmpl, err := template.New("worktreeDir").Parse(pattern)
+	if err != nil {
+		return "", fmt.Errorf("invalid worktreeDir template %q: %w", pattern, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render worktreeDir template %q: %w", pattern, err)
+	}
+
+	path := buf.String()
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(data.Repo, path)
+	}
+	return filepath.Clean(path), nil
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRenderWorktreeDirDefault(t *testing.T) {
+	got, err := renderWorktreeDir(defaultWorktreeDir, worktreeDirData{
+		Repo: "/repo",
+		Name: "feature-a",
+	})
+	if err != nil {
+		t.Fatalf("renderWorktreeDir returned error: %v", err)
+	}
+
+	want := filepath.Join("/repo", ".git", "phantom", "feature-a")
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestRenderWorktreeDirCustomTemplate(t *testing.T) {
+	got, err := renderWorktreeDir("/worktrees/{{.Name}}/{{.Branch}}", worktreeDirData{
+		Repo:   "/repo",
+		Name:   "feature-a",
+		Branch: "feature/feature-a",
+	})
+	if err != nil {
+		t.Fatalf("renderWorktreeDir returned error: %v", err)
+	}
+
+	want := filepath.Join("/worktrees", "feature-a", "feature/feature-a")
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestRenderWorktreeDirRelativeTemplate(t *testing.T) {
+	got, err := renderWorktreeDir(".phantoms/{{.Name}}", worktreeDirData{
+		Repo: "/repo",
+		Name: "feature-a",
+	})
+	if err != nil {
+		t.Fatalf("renderWorktreeDir returned error: %v", err)
+	}
+
+	want := filepath.Join("/repo", ".phantoms", "feature-a")
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestRenderWorktreeDirInvalidTemplate(t *testing.T) {
+	if _, err := renderWorktreeDir("{{.Name", worktreeDirData{Repo: "/repo", Name: "feature-a"}); err == nil {
+		t.Errorf("Expected error for malformed template, got none")
+	}
+}
+
+func TestLoadConfigDefaults(t *testing.T) {
+	tempDir := t.TempDir()
+
+	cfg, err := loadConfig(tempDir)
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	if cfg.WorktreeDir != defaultWorktreeDir {
+		t.Errorf("Expected default worktreeDir %q, got %q", defaultWorktreeDir, cfg.WorktreeDir)
+	}
+}
+
+func TestLoadConfigRepoLocal(t *testing.T) {
+	tempDir := t.TempDir()
+
+	configContent := `
+worktreeDir = ".phantoms/{{.Name}}"
+baseBranch = "main"
+defaultBranchPrefix = "feature/"
+copyFiles = [".env", "node_modules"]
+postCreate = ["npm install"]
+`
+	if err := os.WriteFile(filepath.Join(tempDir, ".phantom.toml"), []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := loadConfig(tempDir)
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+
+	if cfg.WorktreeDir != ".phantoms/{{.Name}}" {
+		t.Errorf("Expected worktreeDir %q, got %q", ".phantoms/{{.Name}}", cfg.WorktreeDir)
+	}
+	if cfg.BaseBranch != "main" {
+		t.Errorf("Expected baseBranch %q, got %q", "main", cfg.BaseBranch)
+	}
+	if cfg.DefaultBranchPrefix != "feature/" {
+		t.Errorf("Expected defaultBranchPrefix %q, got %q", "feature/", cfg.DefaultBranchPrefix)
+	}
+	if len(cfg.CopyFiles) != 2 || cfg.CopyFiles[0] != ".env" || cfg.CopyFiles[1] != "node_modules" {
+		t.Errorf("Unexpected copyFiles: %+v", cfg.CopyFiles)
+	}
+	if len(cfg.PostCreate) != 1 || cfg.PostCreate[0] != "npm install" {
+		t.Errorf("Unexpected postCreate: %+v", cfg.PostCreate)
+	}
+}
@@ -2,32 +2,130 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"strings"
+
+	"github.com/shuymn/phantom/gitutil"
 )
 
 func executeCreate(name string) error {
-	// Get repository root
-	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
-	output, err := cmd.Output()
+	git := newRepoGit()
+
+	// Resolve the main repository root via the git common dir rather than
+	// show-toplevel, so that running from inside an existing phantom creates
+	// a sibling phantom off the main repo instead of nesting under it.
+	commonDir, err := git.RevParseGitCommonDir()
+	if err != nil {
+		return fmt.Errorf("failed to get git common directory: %w", err)
+	}
+	repoRoot := filepath.Dir(commonDir)
+
+	cfg, err := loadConfig(repoRoot)
 	if err != nil {
-		return fmt.Errorf("failed to get repository root: %w", err)
+		return fmt.Errorf("failed to load phantom config: %w", err)
 	}
-	repoRoot := strings.TrimSpace(string(output))
 
-	// Create phantom directory path
-	phantomPath := filepath.Join(repoRoot, ".git", "phantom", name)
+	branch := cfg.DefaultBranchPrefix + name
+
+	phantomPath, err := renderWorktreeDir(cfg.WorktreeDir, worktreeDirData{
+		Repo:   repoRoot,
+		Name:   name,
+		Branch: branch,
+	})
+	if err != nil {
+		return err
+	}
 
-	// Create the worktree
-	cmd = exec.Command("git", "worktree", "add", "-b", name, phantomPath)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := git.WorktreeAdd(phantomPath, branch, cfg.BaseBranch); err != nil {
 		return fmt.Errorf("failed to create worktree: %w", err)
 	}
 
+	if err := copyConfiguredFiles(repoRoot, phantomPath, cfg.CopyFiles); err != nil {
+		removeWorktreeOnFailure(git, phantomPath)
+		return fmt.Errorf("failed to copy configured files: %w", err)
+	}
+
+	if err := runPostCreateHooks(phantomPath, cfg.PostCreate); err != nil {
+		removeWorktreeOnFailure(git, phantomPath)
+		return fmt.Errorf("post-create hook failed: %w", err)
+	}
+
 	fmt.Printf("Successfully created phantom '%s' at %s\n", name, phantomPath)
 	return nil
-}
\ No newline at end of file
+}
+
+// copyConfiguredFiles populates a freshly created worktree with the files
+// listed in cfg.CopyFiles (paths relative to repoRoot). Directories are
+// symlinked; regular files are copied. Missing sources are skipped.
+func copyConfiguredFiles(repoRoot, phantomPath string, files []string) error {
+	for _, name := range files {
+		src := filepath.Join(repoRoot, name)
+		info, err := os.Lstat(src)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to stat %s: %w", src, err)
+		}
+
+		dst := filepath.Join(phantomPath, name)
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", name, err)
+		}
+
+		if info.IsDir() {
+			if err := os.Symlink(src, dst); err != nil {
+				return fmt.Errorf("failed to symlink %s: %w", name, err)
+			}
+			continue
+		}
+
+		if err := copyFile(src, dst, info.Mode()); err != nil {
+			return fmt.Errorf("failed to copy %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func copyFile(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
+
+// runPostCreateHooks runs each hook as a shell command inside dir, in order,
+// streaming its output. It stops at the first failing hook.
+func runPostCreateHooks(dir string, hooks []string) error {
+	for _, hook := range hooks {
+		cmd := exec.Command("sh", "-c", hook)
+		cmd.Dir = dir
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("hook %q: %w", hook, err)
+		}
+	}
+	return nil
+}
+
+// removeWorktreeOnFailure best-effort removes a worktree that failed to
+// finish setup, so a failed `create` doesn't leave a half-initialized phantom
+// behind.
+func removeWorktreeOnFailure(git *gitutil.Git, path string) {
+	if err := git.WorktreeRemove(path, true); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: failed to roll back worktree at %s: %v\n", path, err)
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExecuteCreateWithConfig(t *testing.T) {
+	tempDir := setupTestRepo(t)
+
+	configContent := `
+worktreeDir = ".phantoms/{{.Name}}"
+defaultBranchPrefix = "feature/"
+copyFiles = ["config.local"]
+postCreate = ["echo hello > hook-output.txt"]
+`
+	if err := os.WriteFile(filepath.Join(tempDir, ".phantom.toml"), []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "config.local"), []byte("secret"), 0644); err != nil {
+		t.Fatalf("Failed to write config.local: %v", err)
+	}
+
+	if err := executeCreate("my-feature"); err != nil {
+		t.Fatalf("executeCreate returned error: %v", err)
+	}
+
+	phantomPath := filepath.Join(tempDir, ".phantoms", "my-feature")
+	if _, err := os.Stat(phantomPath); err != nil {
+		t.Fatalf("Expected worktree at %s: %v", phantomPath, err)
+	}
+
+	copied, err := os.ReadFile(filepath.Join(phantomPath, "config.local"))
+	if err != nil {
+		t.Fatalf("Expected config.local to be copied: %v", err)
+	}
+	if string(copied) != "secret" {
+		t.Errorf("Expected copied content %q, got %q", "secret", copied)
+	}
+
+	hookOutput, err := os.ReadFile(filepath.Join(phantomPath, "hook-output.txt"))
+	if err != nil {
+		t.Fatalf("Expected post-create hook to have run: %v", err)
+	}
+	if string(hookOutput) != "hello\n" {
+		t.Errorf("Expected hook output %q, got %q", "hello\n", hookOutput)
+	}
+}
+
+func TestExecuteCreateRollsBackOnFailingHook(t *testing.T) {
+	tempDir := setupTestRepo(t)
+
+	configContent := `
+postCreate = ["exit 1"]
+`
+	if err := os.WriteFile(filepath.Join(tempDir, ".phantom.toml"), []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	err := executeCreate("broken-feature")
+	if err == nil {
+		t.Fatalf("Expected executeCreate to fail when post-create hook fails")
+	}
+
+	phantomPath := filepath.Join(tempDir, ".git", "phantom", "broken-feature")
+	if _, statErr := os.Stat(phantomPath); !os.IsNotExist(statErr) {
+		t.Errorf("Expected worktree at %s to be rolled back, stat err: %v", phantomPath, statErr)
+	}
+}
+
+func TestExecuteCreateHookOrdering(t *testing.T) {
+	tempDir := setupTestRepo(t)
+
+	configContent := `
+postCreate = [
+  "echo first >> order.txt",
+  "echo second >> order.txt",
+]
+`
+	if err := os.WriteFile(filepath.Join(tempDir, ".phantom.toml"), []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	if err := executeCreate("ordered-feature"); err != nil {
+		t.Fatalf("executeCreate returned error: %v", err)
+	}
+
+	phantomPath := filepath.Join(tempDir, ".git", "phantom", "ordered-feature")
+	output, err := os.ReadFile(filepath.Join(phantomPath, "order.txt"))
+	if err != nil {
+		t.Fatalf("Expected order.txt to exist: %v", err)
+	}
+	if string(output) != "first\nsecond\n" {
+		t.Errorf("Expected hooks to run in order, got %q", output)
+	}
+}
@@ -161,6 +161,28 @@ func TestExecuteCreateDuplicateName(t *testing.T) {
 	}
 }
 
+func TestExecuteCreateFromInsidePhantom(t *testing.T) {
+	tempDir := setupTestRepo(t)
+
+	if err := executeCreate("feature-a"); err != nil {
+		t.Fatalf("Failed to create phantom: %v", err)
+	}
+
+	phantomPath := filepath.Join(tempDir, ".git", "phantom", "feature-a")
+	if err := os.Chdir(phantomPath); err != nil {
+		t.Fatalf("Failed to chdir into phantom: %v", err)
+	}
+
+	if err := executeCreate("feature-b"); err != nil {
+		t.Fatalf("executeCreate returned error when run from inside a phantom: %v", err)
+	}
+
+	siblingPath := filepath.Join(tempDir, ".git", "phantom", "feature-b")
+	if _, err := os.Stat(siblingPath); err != nil {
+		t.Errorf("Expected sibling phantom at %s, got: %v", siblingPath, err)
+	}
+}
+
 func TestExecuteCreateOutsideGitRepo(t *testing.T) {
 	// Create a temporary directory (not a git repo)
 	tempDir := t.TempDir()
@@ -181,4 +203,4 @@ func TestExecuteCreateOutsideGitRepo(t *testing.T) {
 	if err == nil {
 		t.Errorf("Expected error when creating phantom outside git repo, but got none")
 	}
-}
\ No newline at end of file
+}
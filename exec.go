@@ -0,0 +1,215 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+)
+
+func executeExec(args []string) error {
+	before, after := splitAtDoubleDash(args)
+	if len(after) == 0 {
+		return fmt.Errorf("exec requires a command after --, e.g. git phantom exec <name> -- <cmd> [args...]")
+	}
+
+	flags := flag.NewFlagSet("exec", flag.ContinueOnError)
+	all := flags.Bool("all", false, "run across every phantom")
+	jobs := flags.Int("jobs", 1, "maximum number of phantoms to run concurrently with --all")
+	continueOnError := flags.Bool("continue-on-error", false, "keep running the remaining phantoms after a failure")
+	if err := flags.Parse(before); err != nil {
+		return err
+	}
+
+	git := newRepoGit()
+	commonDir, err := git.RevParseGitCommonDir()
+	if err != nil {
+		return fmt.Errorf("failed to get git common directory: %w", err)
+	}
+	phantomRoot := filepath.Join(commonDir, "phantom")
+
+	var names []string
+	if *all {
+		names, err = listPhantomNames(git, phantomRoot)
+		if err != nil {
+			return err
+		}
+	} else {
+		rest := flags.Args()
+		if len(rest) < 1 {
+			return fmt.Errorf("exec requires a phantom name or --all")
+		}
+		names = rest[:1]
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("no phantom worktrees found")
+	}
+
+	workers := *jobs
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(names) {
+		workers = len(names)
+	}
+
+	return runExec(phantomRoot, names, after[0], after[1:], workers, *continueOnError)
+}
+
+// splitAtDoubleDash splits args on the first literal "--", returning the
+// phantom-facing flags/name before it and the command to run after it.
+func splitAtDoubleDash(args []string) (before, after []string) {
+	for i, a := range args {
+		if a == "--" {
+			return args[:i], args[i+1:]
+		}
+	}
+	return args, nil
+}
+
+// runExec runs command with cmdArgs inside each named phantom's worktree, at
+// most workers at a time, prefixing every output line with the phantom name.
+// It returns an error wrapping the highest exit code among all runs, or the
+// first non-exit error encountered (e.g. the command couldn't be started).
+// Unless continueOnError is set, a failure cancels any phantoms not yet started.
+func runExec(phantomRoot string, names []string, command string, cmdArgs []string, workers int, continueOnError bool) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	jobs := make(chan string)
+	go func() {
+		defer close(jobs)
+		for _, name := range names {
+			select {
+			case jobs <- name:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		mu          sync.Mutex
+		highestCode int
+		firstErr    error
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range jobs {
+				code, err := execInPhantom(filepath.Join(phantomRoot, name), name, command, cmdArgs)
+
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = err
+				}
+				if code > highestCode {
+					highestCode = code
+				}
+				failed := err != nil || code != 0
+				mu.Unlock()
+
+				if failed && !continueOnError {
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	if highestCode != 0 {
+		return &exitCodeError{code: highestCode}
+	}
+	return nil
+}
+
+// execInPhantom runs command with cmdArgs in dir, streaming its output
+// through a writer that prefixes every line with "[name] ".
+func execInPhantom(dir, name, command string, cmdArgs []string) (int, error) {
+	stdout := newPrefixWriter(os.Stdout, name)
+	stderr := newPrefixWriter(os.Stderr, name)
+	defer stdout.Flush()
+	defer stderr.Flush()
+
+	cmd := exec.Command(command, cmdArgs...)
+	cmd.Dir = dir
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return exitErr.ExitCode(), nil
+		}
+		return 1, fmt.Errorf("%s: %w", name, err)
+	}
+	return 0, nil
+}
+
+// exitCodeError lets main propagate a failing phantom's exit code via
+// os.Exit, without main needing to know about exec's internals.
+type exitCodeError struct {
+	code int
+}
+
+func (e *exitCodeError) Error() string {
+	return fmt.Sprintf("command exited with status %d", e.code)
+}
+
+func (e *exitCodeError) ExitCode() int {
+	return e.code
+}
+
+// prefixWriter prefixes every complete line written to it with a fixed
+// prefix before forwarding it to out, buffering any trailing partial line
+// until the next write or an explicit Flush.
+type prefixWriter struct {
+	mu     sync.Mutex
+	out    io.Writer
+	prefix string
+	buf    []byte
+}
+
+func newPrefixWriter(out io.Writer, name string) *prefixWriter {
+	return &prefixWriter{out: out, prefix: fmt.Sprintf("[%s] ", name)}
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if _, err := fmt.Fprintf(w.out, "%s%s\n", w.prefix, w.buf[:i]); err != nil {
+			return len(p), err
+		}
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush writes out any buffered partial line that never ended in a newline.
+func (w *prefixWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) > 0 {
+		fmt.Fprintf(w.out, "%s%s\n", w.prefix, w.buf)
+		w.buf = nil
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExecuteExecSingle(t *testing.T) {
+	tempDir := setupTestRepo(t)
+
+	if err := executeCreate("feature-a"); err != nil {
+		t.Fatalf("Failed to create phantom: %v", err)
+	}
+
+	err := executeExec([]string{"feature-a", "--", "sh", "-c", "echo ran > marker.txt"})
+	if err != nil {
+		t.Fatalf("executeExec returned error: %v", err)
+	}
+
+	marker := filepath.Join(tempDir, ".git", "phantom", "feature-a", "marker.txt")
+	if _, err := os.Stat(marker); err != nil {
+		t.Errorf("Expected marker file in phantom worktree: %v", err)
+	}
+}
+
+func TestExecuteExecAll(t *testing.T) {
+	tempDir := setupTestRepo(t)
+
+	for _, name := range []string{"feature-a", "feature-b", "feature-c"} {
+		if err := executeCreate(name); err != nil {
+			t.Fatalf("Failed to create phantom %q: %v", name, err)
+		}
+	}
+
+	err := executeExec([]string{"--all", "--jobs=2", "--", "sh", "-c", "echo ran > marker.txt"})
+	if err != nil {
+		t.Fatalf("executeExec returned error: %v", err)
+	}
+
+	for _, name := range []string{"feature-a", "feature-b", "feature-c"} {
+		marker := filepath.Join(tempDir, ".git", "phantom", name, "marker.txt")
+		if _, err := os.Stat(marker); err != nil {
+			t.Errorf("Expected marker file for phantom %q: %v", name, err)
+		}
+	}
+}
+
+func TestExecuteExecAllFromInsidePhantom(t *testing.T) {
+	tempDir := setupTestRepo(t)
+
+	for _, name := range []string{"feature-a", "feature-b"} {
+		if err := executeCreate(name); err != nil {
+			t.Fatalf("Failed to create phantom %q: %v", name, err)
+		}
+	}
+
+	phantomPath := filepath.Join(tempDir, ".git", "phantom", "feature-a")
+	if err := os.Chdir(phantomPath); err != nil {
+		t.Fatalf("Failed to chdir into phantom: %v", err)
+	}
+
+	err := executeExec([]string{"--all", "--", "sh", "-c", "echo ran > marker.txt"})
+	if err != nil {
+		t.Fatalf("executeExec returned error when run from inside a phantom: %v", err)
+	}
+
+	for _, name := range []string{"feature-a", "feature-b"} {
+		marker := filepath.Join(tempDir, ".git", "phantom", name, "marker.txt")
+		if _, err := os.Stat(marker); err != nil {
+			t.Errorf("Expected marker file for phantom %q: %v", name, err)
+		}
+	}
+}
+
+func TestExecuteExecPropagatesHighestExitCode(t *testing.T) {
+	setupTestRepo(t)
+
+	for _, name := range []string{"feature-a", "feature-b"} {
+		if err := executeCreate(name); err != nil {
+			t.Fatalf("Failed to create phantom %q: %v", name, err)
+		}
+	}
+
+	err := executeExec([]string{"--all", "--continue-on-error", "--", "sh", "-c", "exit 3"})
+	if err == nil {
+		t.Fatalf("Expected executeExec to return an error")
+	}
+
+	coder, ok := err.(interface{ ExitCode() int })
+	if !ok {
+		t.Fatalf("Expected error to expose ExitCode(), got %T: %v", err, err)
+	}
+	if coder.ExitCode() != 3 {
+		t.Errorf("Expected exit code 3, got %d", coder.ExitCode())
+	}
+}
+
+func TestExecuteExecFailFastStopsRemainingPhantoms(t *testing.T) {
+	tempDir := setupTestRepo(t)
+
+	if err := executeCreate("feature-a"); err != nil {
+		t.Fatalf("Failed to create phantom: %v", err)
+	}
+	if err := executeCreate("feature-b"); err != nil {
+		t.Fatalf("Failed to create phantom: %v", err)
+	}
+
+	// feature-a (processed first, alphabetically, by the single worker) fails
+	// immediately; feature-b would write marker.txt if it got a chance to run.
+	script := `case "$PWD" in *feature-a) exit 1 ;; *) echo ran > marker.txt ;; esac`
+	err := executeExec([]string{"--all", "--jobs=1", "--", "sh", "-c", script})
+	if err == nil {
+		t.Fatalf("Expected executeExec to return an error")
+	}
+
+	marker := filepath.Join(tempDir, ".git", "phantom", "feature-b", "marker.txt")
+	if _, statErr := os.Stat(marker); !os.IsNotExist(statErr) {
+		t.Errorf("Expected feature-b to be skipped after feature-a failed fast, stat err: %v", statErr)
+	}
+}
+
+func TestExecuteExecRequiresCommand(t *testing.T) {
+	setupTestRepo(t)
+
+	if err := executeExec([]string{"feature-a"}); err == nil {
+		t.Errorf("Expected error when no command is given after --")
+	}
+}
+
+func TestExecuteExecUnknownPhantom(t *testing.T) {
+	setupTestRepo(t)
+
+	if err := executeExec([]string{"does-not-exist", "--", "true"}); err == nil {
+		t.Errorf("Expected error for unknown phantom, got none")
+	}
+}
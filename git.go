@@ -0,0 +1,11 @@
+package main
+
+import "github.com/shuymn/phantom/gitutil"
+
+// newRepoGit returns a gitutil.Git bound to the current working directory,
+// running real git commands. Subcommands rebind it to a specific worktree
+// (via Git.WithDir) when they need to operate on a phantom rather than the
+// checkout the user is currently in.
+func newRepoGit() *gitutil.Git {
+	return gitutil.New(gitutil.ExecRunner{}, ".")
+}
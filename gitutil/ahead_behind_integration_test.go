@@ -0,0 +1,63 @@
+package gitutil
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// TestAheadBehindUsesBranchsOwnUpstream guards against AheadBehind silently
+// reporting HEAD's upstream counts instead of the requested branch's: it
+// binds Git to a directory whose checked-out branch (main) differs from the
+// branch being queried (feature), and checks the numbers match feature's own
+// tracking branch.
+func TestAheadBehindUsesBranchsOwnUpstream(t *testing.T) {
+	dir := t.TempDir()
+	runGit := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	runGit("init", "-b", "main")
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# test"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	runGit("add", ".")
+	runGit("commit", "-m", "initial")
+
+	// feature-base stays at the initial commit and acts as feature's
+	// upstream; main has no upstream configured at all.
+	runGit("branch", "feature-base")
+	runGit("checkout", "-b", "feature")
+	for i := 0; i < 2; i++ {
+		path := filepath.Join(dir, fmt.Sprintf("file%d.txt", i))
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		runGit("add", ".")
+		runGit("commit", "-m", fmt.Sprintf("feature commit %d", i))
+	}
+	runGit("branch", "--set-upstream-to=feature-base", "feature")
+
+	// HEAD is main, which differs from the branch we're about to query.
+	runGit("checkout", "main")
+
+	g := New(ExecRunner{}, dir)
+	ahead, behind, err := g.AheadBehind("feature")
+	if err != nil {
+		t.Fatalf("AheadBehind returned error: %v", err)
+	}
+	if ahead != 2 || behind != 0 {
+		t.Errorf("Expected ahead=2 behind=0 for feature vs. its own upstream, got ahead=%d behind=%d", ahead, behind)
+	}
+}
@@ -0,0 +1,50 @@
+package gitutil
+
+// fakeCall records a single invocation made against a fakeRunner.
+type fakeCall struct {
+	dir  string
+	args []string
+}
+
+// fakeRunner is a Runner that records every invocation and returns
+// pre-programmed responses, keyed by the joined args, instead of shelling
+// out to git.
+type fakeRunner struct {
+	calls     []fakeCall
+	responses map[string]fakeResponse
+}
+
+type fakeResponse struct {
+	stdout string
+	stderr string
+	err    error
+}
+
+func newFakeRunner() *fakeRunner {
+	return &fakeRunner{responses: map[string]fakeResponse{}}
+}
+
+func (f *fakeRunner) on(response fakeResponse, args ...string) {
+	f.responses[joinArgs(args)] = response
+}
+
+func (f *fakeRunner) Run(dir string, args ...string) (stdout, stderr string, err error) {
+	f.calls = append(f.calls, fakeCall{dir: dir, args: args})
+
+	resp, ok := f.responses[joinArgs(args)]
+	if !ok {
+		return "", "", nil
+	}
+	return resp.stdout, resp.stderr, resp.err
+}
+
+func joinArgs(args []string) string {
+	out := ""
+	for i, a := range args {
+		if i > 0 {
+			out += " "
+		}
+		out += a
+	}
+	return out
+}
@@ -0,0 +1,103 @@
+// Package gitutil provides a small, testable wrapper around the git
+// subcommands phantom shells out to. It centralizes command construction and
+// error reporting so callers don't have to deal with os/exec directly, and
+// so tests can inject a fake Runner instead of spinning up a real repository.
+package gitutil
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Runner executes a git command with the given working directory and
+// arguments (excluding the leading "git"), returning its stdout, stderr, and
+// any error from running it.
+type Runner interface {
+	Run(dir string, args ...string) (stdout, stderr string, err error)
+}
+
+// ExecRunner runs git via os/exec. It is the Runner used outside of tests.
+type ExecRunner struct{}
+
+func (ExecRunner) Run(dir string, args ...string) (stdout, stderr string, err error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+
+	var stdoutBuf, stderrBuf strings.Builder
+	cmd.Stdout = &stdoutBuf
+	cmd.Stderr = &stderrBuf
+
+	err = cmd.Run()
+	return stdoutBuf.String(), stderrBuf.String(), err
+}
+
+// Git runs git commands scoped to a single working directory.
+type Git struct {
+	runner Runner
+	dir    string
+}
+
+// New returns a Git bound to dir, using runner to execute commands.
+func New(runner Runner, dir string) *Git {
+	return &Git{runner: runner, dir: dir}
+}
+
+// WithDir returns a copy of g bound to a different working directory,
+// reusing the same Runner. It's used to operate on a specific worktree
+// (e.g. a phantom) rather than the main repository checkout.
+func (g *Git) WithDir(dir string) *Git {
+	return &Git{runner: g.runner, dir: dir}
+}
+
+// Dir returns the working directory this Git is bound to.
+func (g *Git) Dir() string {
+	return g.dir
+}
+
+// run executes a git command and wraps any failure in a *GitError carrying
+// enough context (args, working dir, stdout, stderr) to debug it.
+func (g *Git) run(args ...string) (string, error) {
+	stdout, stderr, err := g.runner.Run(g.dir, args...)
+	if err != nil {
+		return stdout, &GitError{Args: args, Dir: g.dir, Stdout: stdout, Stderr: stderr, Err: err}
+	}
+	return stdout, nil
+}
+
+// GitError reports the failure of a single git invocation, along with the
+// context needed to diagnose it.
+type GitError struct {
+	Args   []string
+	Dir    string
+	Stdout string
+	Stderr string
+	Err    error
+}
+
+func (e *GitError) Error() string {
+	stderr := strings.TrimSpace(e.Stderr)
+	if stderr == "" {
+		return fmt.Sprintf("git %s (in %s): %v", strings.Join(e.Args, " "), e.Dir, e.Err)
+	}
+	return fmt.Sprintf("git %s (in %s): %v: %s", strings.Join(e.Args, " "), e.Dir, e.Err, stderr)
+}
+
+func (e *GitError) Unwrap() error {
+	return e.Err
+}
+
+// exitCoder is implemented by *exec.ExitError; it's declared as an interface
+// so tests can fake a process exit code without spawning a real process.
+type exitCoder interface {
+	ExitCode() int
+}
+
+// ExitCode returns the process exit code of the failed invocation, or -1 if
+// it isn't available (e.g. the binary couldn't be started at all).
+func (e *GitError) ExitCode() int {
+	if ec, ok := e.Err.(exitCoder); ok {
+		return ec.ExitCode()
+	}
+	return -1
+}
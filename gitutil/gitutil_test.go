@@ -0,0 +1,154 @@
+package gitutil
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRevParseShowToplevel(t *testing.T) {
+	runner := newFakeRunner()
+	runner.on(fakeResponse{stdout: "/repo\n"}, "rev-parse", "--show-toplevel")
+
+	g := New(runner, "/repo/sub")
+	got, err := g.RevParseShowToplevel()
+	if err != nil {
+		t.Fatalf("RevParseShowToplevel returned error: %v", err)
+	}
+	if got != "/repo" {
+		t.Errorf("Expected %q, got %q", "/repo", got)
+	}
+	if len(runner.calls) != 1 || runner.calls[0].dir != "/repo/sub" {
+		t.Errorf("Expected one call in /repo/sub, got %+v", runner.calls)
+	}
+}
+
+func TestIsDirty(t *testing.T) {
+	cases := []struct {
+		name   string
+		stdout string
+		want   bool
+	}{
+		{"clean", "", false},
+		{"dirty", " M foo.go\n", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := newFakeRunner()
+			runner.on(fakeResponse{stdout: tc.stdout}, "status", "--porcelain")
+
+			got, err := New(runner, "/repo").IsDirty()
+			if err != nil {
+				t.Fatalf("IsDirty returned error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("Expected dirty=%v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestAheadBehind(t *testing.T) {
+	runner := newFakeRunner()
+	runner.on(fakeResponse{stdout: "2\t3\n"}, "rev-list", "--left-right", "--count", "main...main@{upstream}")
+
+	ahead, behind, err := New(runner, "/repo").AheadBehind("main")
+	if err != nil {
+		t.Fatalf("AheadBehind returned error: %v", err)
+	}
+	if ahead != 2 || behind != 3 {
+		t.Errorf("Expected ahead=2 behind=3, got ahead=%d behind=%d", ahead, behind)
+	}
+}
+
+func TestMergeBaseIsAncestor(t *testing.T) {
+	t.Run("merged", func(t *testing.T) {
+		runner := newFakeRunner()
+		runner.on(fakeResponse{}, "merge-base", "--is-ancestor", "feature", "main")
+
+		merged, err := New(runner, "/repo").MergeBaseIsAncestor("feature", "main")
+		if err != nil {
+			t.Fatalf("MergeBaseIsAncestor returned error: %v", err)
+		}
+		if !merged {
+			t.Errorf("Expected merged=true")
+		}
+	})
+
+	t.Run("not merged", func(t *testing.T) {
+		runner := newFakeRunner()
+		runner.on(fakeResponse{err: &exitError{code: 1}}, "merge-base", "--is-ancestor", "feature", "main")
+
+		merged, err := New(runner, "/repo").MergeBaseIsAncestor("feature", "main")
+		if err != nil {
+			t.Fatalf("MergeBaseIsAncestor returned error: %v", err)
+		}
+		if merged {
+			t.Errorf("Expected merged=false")
+		}
+	})
+}
+
+func TestWorktreeAdd(t *testing.T) {
+	runner := newFakeRunner()
+	runner.on(fakeResponse{}, "worktree", "add", "-b", "feature-a", "/repo/.git/phantom/feature-a", "main")
+
+	if err := New(runner, "/repo").WorktreeAdd("/repo/.git/phantom/feature-a", "feature-a", "main"); err != nil {
+		t.Fatalf("WorktreeAdd returned error: %v", err)
+	}
+}
+
+func TestWorktreeList(t *testing.T) {
+	porcelain := "worktree /repo\n" +
+		"HEAD abc123\n" +
+		"branch refs/heads/main\n" +
+		"\n" +
+		"worktree /repo/.git/phantom/feature-a\n" +
+		"HEAD def456\n" +
+		"branch refs/heads/feature-a\n" +
+		"\n"
+
+	runner := newFakeRunner()
+	runner.on(fakeResponse{stdout: porcelain}, "worktree", "list", "--porcelain")
+
+	worktrees, err := New(runner, "/repo").WorktreeList()
+	if err != nil {
+		t.Fatalf("WorktreeList returned error: %v", err)
+	}
+	if len(worktrees) != 2 {
+		t.Fatalf("Expected 2 worktrees, got %d: %+v", len(worktrees), worktrees)
+	}
+	if worktrees[1].Path != "/repo/.git/phantom/feature-a" || worktrees[1].Branch != "feature-a" {
+		t.Errorf("Unexpected second worktree: %+v", worktrees[1])
+	}
+}
+
+func TestGitErrorWrapsFailure(t *testing.T) {
+	runner := newFakeRunner()
+	underlying := errors.New("exit status 128")
+	runner.on(fakeResponse{stderr: "fatal: not a git repository\n", err: underlying}, "rev-parse", "--show-toplevel")
+
+	_, err := New(runner, "/tmp").RevParseShowToplevel()
+	if err == nil {
+		t.Fatalf("Expected error, got none")
+	}
+
+	var gitErr *GitError
+	if !errors.As(err, &gitErr) {
+		t.Fatalf("Expected *GitError, got %T: %v", err, err)
+	}
+	if gitErr.Dir != "/tmp" {
+		t.Errorf("Expected Dir %q, got %q", "/tmp", gitErr.Dir)
+	}
+	if !errors.Is(err, underlying) {
+		t.Errorf("Expected Unwrap to expose the underlying error")
+	}
+}
+
+// exitError is a minimal stand-in for *exec.ExitError, used to exercise
+// GitError.ExitCode without spawning a real process.
+type exitError struct {
+	code int
+}
+
+func (e *exitError) Error() string { return "exit error" }
+func (e *exitError) ExitCode() int { return e.code }
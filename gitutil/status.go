@@ -0,0 +1,88 @@
+package gitutil
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// RevParseShowToplevel returns the root directory of the worktree g is bound to.
+func (g *Git) RevParseShowToplevel() (string, error) {
+	out, err := g.run("rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// RevParseGitCommonDir returns the absolute path of the repository's common
+// .git directory, which is shared by all of its worktrees.
+func (g *Git) RevParseGitCommonDir() (string, error) {
+	out, err := g.run("rev-parse", "--git-common-dir")
+	if err != nil {
+		return "", err
+	}
+	dir := strings.TrimSpace(out)
+	if !filepath.IsAbs(dir) {
+		abs, err := filepath.Abs(filepath.Join(g.dir, dir))
+		if err != nil {
+			return "", err
+		}
+		dir = abs
+	}
+	return dir, nil
+}
+
+// IsDirty reports whether the working tree has uncommitted changes.
+func (g *Git) IsDirty() (bool, error) {
+	out, err := g.run("status", "--porcelain")
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(out) != "", nil
+}
+
+// CurrentBranch returns the name of the branch currently checked out.
+func (g *Git) CurrentBranch() (string, error) {
+	out, err := g.run("rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// AheadBehind reports how many commits branch is ahead and behind its
+// upstream, respectively.
+func (g *Git) AheadBehind(branch string) (ahead, behind int, err error) {
+	out, err := g.run("rev-list", "--left-right", "--count", branch+"..."+branch+"@{upstream}")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	fields := strings.Fields(out)
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("unexpected rev-list output: %q", out)
+	}
+	ahead, err = strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	behind, err = strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	return ahead, behind, nil
+}
+
+// MergeBaseIsAncestor reports whether branch is fully merged into base.
+func (g *Git) MergeBaseIsAncestor(branch, base string) (bool, error) {
+	_, err := g.run("merge-base", "--is-ancestor", branch, base)
+	if err == nil {
+		return true, nil
+	}
+	if gitErr, ok := err.(*GitError); ok && gitErr.ExitCode() == 1 {
+		return false, nil
+	}
+	return false, err
+}
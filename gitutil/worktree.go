@@ -0,0 +1,120 @@
+package gitutil
+
+import (
+	"bufio"
+	"strings"
+)
+
+// Worktree is a single entry from `git worktree list --porcelain`.
+type Worktree struct {
+	Path     string `json:"path"`
+	Head     string `json:"head"`
+	Branch   string `json:"branch,omitempty"`
+	Bare     bool   `json:"bare,omitempty"`
+	Detached bool   `json:"detached,omitempty"`
+	Locked   string `json:"locked,omitempty"`
+	Prunable string `json:"prunable,omitempty"`
+}
+
+// WorktreeList runs `git worktree list --porcelain` and parses its output.
+func (g *Git) WorktreeList() ([]Worktree, error) {
+	out, err := g.run("worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	return parseWorktreePorcelain(out), nil
+}
+
+func parseWorktreePorcelain(output string) []Worktree {
+	var result []Worktree
+	var current *Worktree
+
+	flush := func() {
+		if current != nil {
+			result = append(result, *current)
+			current = nil
+		}
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			flush()
+			continue
+		}
+
+		field, rest, _ := strings.Cut(line, " ")
+		switch field {
+		case "worktree":
+			flush()
+			current = &Worktree{Path: rest}
+		case "HEAD":
+			if current != nil {
+				current.Head = rest
+			}
+		case "branch":
+			if current != nil {
+				current.Branch = strings.TrimPrefix(rest, "refs/heads/")
+			}
+		case "bare":
+			if current != nil {
+				current.Bare = true
+			}
+		case "detached":
+			if current != nil {
+				current.Detached = true
+			}
+		case "locked":
+			if current != nil {
+				current.Locked = rest
+			}
+		case "prunable":
+			if current != nil {
+				current.Prunable = rest
+			}
+		}
+	}
+	flush()
+
+	return result
+}
+
+// WorktreeAdd creates a new worktree at path on a new branch, checked out
+// from base. If base is empty, the branch is created from the current HEAD.
+func (g *Git) WorktreeAdd(path, branch, base string) error {
+	args := []string{"worktree", "add", "-b", branch, path}
+	if base != "" {
+		args = append(args, base)
+	}
+	_, err := g.run(args...)
+	return err
+}
+
+// WorktreeRemove removes the worktree at path, forcing the removal if force
+// is set (e.g. despite uncommitted changes).
+func (g *Git) WorktreeRemove(path string, force bool) error {
+	args := []string{"worktree", "remove", path}
+	if force {
+		args = append(args, "--force")
+	}
+	_, err := g.run(args...)
+	return err
+}
+
+// WorktreePrune removes administrative files for worktrees whose working
+// directory has been deleted, reporting what it removes.
+func (g *Git) WorktreePrune() (string, error) {
+	return g.run("worktree", "prune", "-v")
+}
+
+// BranchDelete deletes branch, forcing the deletion (even if unmerged) when
+// force is set.
+func (g *Git) BranchDelete(branch string, force bool) error {
+	flag := "-d"
+	if force {
+		flag = "-D"
+	}
+	_, err := g.run("branch", flag, branch)
+	return err
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/shuymn/phantom/gitutil"
+)
+
+// Worktree augments a raw gitutil.Worktree with phantom-specific and status
+// information.
+type Worktree struct {
+	gitutil.Worktree
+
+	Name    string `json:"name"`
+	Ahead   int    `json:"ahead"`
+	Behind  int    `json:"behind"`
+	Dirty   bool   `json:"dirty"`
+	Phantom bool   `json:"phantom"`
+}
+
+func executeList(args []string) error {
+	flags := flag.NewFlagSet("list", flag.ContinueOnError)
+	format := flags.String("format", "table", "output format: table, json, porcelain")
+	all := flags.Bool("all", false, "include non-phantom worktrees")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	git := newRepoGit()
+	commonDir, err := git.RevParseGitCommonDir()
+	if err != nil {
+		return fmt.Errorf("failed to get git common directory: %w", err)
+	}
+	phantomRoot := filepath.Join(commonDir, "phantom")
+
+	worktrees, err := git.WorktreeList()
+	if err != nil {
+		return fmt.Errorf("failed to list worktrees: %w", err)
+	}
+
+	var result []Worktree
+	for _, wt := range worktrees {
+		entry := Worktree{Worktree: wt}
+		entry.Phantom = isUnder(phantomRoot, wt.Path)
+		if !entry.Phantom && !*all {
+			continue
+		}
+		if entry.Phantom {
+			entry.Name = strings.TrimPrefix(wt.Path, phantomRoot+string(filepath.Separator))
+		} else {
+			entry.Name = filepath.Base(wt.Path)
+		}
+
+		wtGit := git.WithDir(wt.Path)
+		if wt.Branch != "" {
+			if ahead, behind, err := wtGit.AheadBehind(wt.Branch); err == nil {
+				entry.Ahead, entry.Behind = ahead, behind
+			}
+		}
+		entry.Dirty, _ = wtGit.IsDirty()
+
+		result = append(result, entry)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	switch *format {
+	case "json":
+		return printListJSON(result)
+	case "porcelain":
+		return printListPorcelain(result)
+	case "table":
+		return printListTable(result)
+	default:
+		return fmt.Errorf("unknown format %q: must be table, json, or porcelain", *format)
+	}
+}
+
+func printListJSON(worktrees []Worktree) error {
+	if worktrees == nil {
+		worktrees = []Worktree{}
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(worktrees)
+}
+
+func printListPorcelain(worktrees []Worktree) error {
+	for _, wt := range worktrees {
+		fmt.Printf("worktree %s\n", wt.Path)
+		fmt.Printf("name %s\n", wt.Name)
+		fmt.Printf("branch %s\n", wt.Branch)
+		fmt.Printf("ahead %d\n", wt.Ahead)
+		fmt.Printf("behind %d\n", wt.Behind)
+		fmt.Printf("dirty %t\n", wt.Dirty)
+		fmt.Println()
+	}
+	return nil
+}
+
+func printListTable(worktrees []Worktree) error {
+	if len(worktrees) == 0 {
+		fmt.Println("No phantom worktrees found.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tBRANCH\tAHEAD\tBEHIND\tDIRTY\tPATH")
+	for _, wt := range worktrees {
+		dirty := ""
+		if wt.Dirty {
+			dirty = "*"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\t%s\n", wt.Name, wt.Branch, wt.Ahead, wt.Behind, dirty, wt.Path)
+	}
+	return w.Flush()
+}
+
+// isUnder reports whether path is root or a descendant of root.
+func isUnder(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
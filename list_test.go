@@ -0,0 +1,192 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func setupTestRepo(t *testing.T) string {
+	t.Helper()
+
+	tempDir := t.TempDir()
+
+	cmd := exec.Command("git", "init")
+	cmd.Dir = tempDir
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("Failed to initialize git repository: %v", err)
+	}
+
+	testFile := filepath.Join(tempDir, "README.md")
+	if err := os.WriteFile(testFile, []byte("# Test Repository"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	cmd = exec.Command("git", "add", ".")
+	cmd.Dir = tempDir
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("Failed to add files: %v", err)
+	}
+
+	cmd = exec.Command("git", "commit", "-m", "Initial commit")
+	cmd.Dir = tempDir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Test",
+		"GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=Test",
+		"GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	t.Cleanup(func() { os.Chdir(originalDir) })
+
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	return tempDir
+}
+
+func TestExecuteListJSON(t *testing.T) {
+	tempDir := setupTestRepo(t)
+
+	for _, name := range []string{"feature-a", "feature-b"} {
+		if err := executeCreate(name); err != nil {
+			t.Fatalf("Failed to create phantom %q: %v", name, err)
+		}
+	}
+
+	stdout, err := captureStdout(t, func() error {
+		return executeList([]string{"--format=json"})
+	})
+	if err != nil {
+		t.Fatalf("executeList returned error: %v", err)
+	}
+
+	var worktrees []Worktree
+	if err := json.Unmarshal([]byte(stdout), &worktrees); err != nil {
+		t.Fatalf("Failed to unmarshal JSON output: %v\noutput: %s", err, stdout)
+	}
+
+	if len(worktrees) != 2 {
+		t.Fatalf("Expected 2 phantom worktrees, got %d: %+v", len(worktrees), worktrees)
+	}
+
+	names := map[string]bool{}
+	for _, wt := range worktrees {
+		names[wt.Name] = true
+		if !wt.Phantom {
+			t.Errorf("Expected worktree %q to be marked as phantom", wt.Name)
+		}
+		wantPath := filepath.Join(tempDir, ".git", "phantom", wt.Name)
+		if wt.Path != wantPath {
+			t.Errorf("Expected path %q, got %q", wantPath, wt.Path)
+		}
+	}
+	if !names["feature-a"] || !names["feature-b"] {
+		t.Errorf("Expected feature-a and feature-b in output, got %+v", names)
+	}
+}
+
+func TestExecuteListExcludesMainWorktreeByDefault(t *testing.T) {
+	setupTestRepo(t)
+
+	if err := executeCreate("feature-a"); err != nil {
+		t.Fatalf("Failed to create phantom: %v", err)
+	}
+
+	stdout, err := captureStdout(t, func() error {
+		return executeList([]string{"--format=json"})
+	})
+	if err != nil {
+		t.Fatalf("executeList returned error: %v", err)
+	}
+
+	var worktrees []Worktree
+	if err := json.Unmarshal([]byte(stdout), &worktrees); err != nil {
+		t.Fatalf("Failed to unmarshal JSON output: %v", err)
+	}
+
+	if len(worktrees) != 1 {
+		t.Fatalf("Expected only the phantom worktree without --all, got %d: %+v", len(worktrees), worktrees)
+	}
+}
+
+func TestExecuteListFromInsidePhantom(t *testing.T) {
+	tempDir := setupTestRepo(t)
+
+	for _, name := range []string{"feature-a", "feature-b"} {
+		if err := executeCreate(name); err != nil {
+			t.Fatalf("Failed to create phantom %q: %v", name, err)
+		}
+	}
+
+	phantomPath := filepath.Join(tempDir, ".git", "phantom", "feature-a")
+	if err := os.Chdir(phantomPath); err != nil {
+		t.Fatalf("Failed to chdir into phantom: %v", err)
+	}
+
+	stdout, err := captureStdout(t, func() error {
+		return executeList([]string{"--format=json", "--all"})
+	})
+	if err != nil {
+		t.Fatalf("executeList returned error: %v", err)
+	}
+
+	var worktrees []Worktree
+	if err := json.Unmarshal([]byte(stdout), &worktrees); err != nil {
+		t.Fatalf("Failed to unmarshal JSON output: %v\noutput: %s", err, stdout)
+	}
+
+	if len(worktrees) != 3 {
+		t.Fatalf("Expected 3 worktrees (main + 2 phantoms) when run from inside a phantom, got %d: %+v", len(worktrees), worktrees)
+	}
+}
+
+func TestExecuteListInvalidFormat(t *testing.T) {
+	setupTestRepo(t)
+
+	err := executeList([]string{"--format=xml"})
+	if err == nil {
+		t.Errorf("Expected error for invalid format, got none")
+	}
+}
+
+// captureStdout redirects os.Stdout for the duration of fn and returns what
+// was written to it.
+func captureStdout(t *testing.T, fn func() error) (string, error) {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+
+	original := os.Stdout
+	os.Stdout = w
+
+	fnErr := fn()
+
+	w.Close()
+	os.Stdout = original
+
+	buf := make([]byte, 0, 4096)
+	chunk := make([]byte, 4096)
+	for {
+		n, err := r.Read(chunk)
+		buf = append(buf, chunk[:n]...)
+		if err != nil {
+			break
+		}
+	}
+
+	return string(buf), fnErr
+}
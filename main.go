@@ -25,15 +25,35 @@ func main() {
 			os.Exit(1)
 		}
 	case "list":
-		fmt.Println("TODO: Implement list command")
+		if err := executeList(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 	case "add":
 		fmt.Println("TODO: Implement add command")
 	case "switch":
-		fmt.Println("TODO: Implement switch command")
+		if err := executeSwitch(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 	case "remove":
-		fmt.Println("TODO: Implement remove command")
+		if err := executeRemove(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 	case "prune":
-		fmt.Println("TODO: Implement prune command")
+		if err := executePrune(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	case "exec":
+		if err := executeExec(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			if coder, ok := err.(interface{ ExitCode() int }); ok {
+				os.Exit(coder.ExitCode())
+			}
+			os.Exit(1)
+		}
 	case "help", "-h", "--help":
 		printUsage()
 	default:
@@ -56,5 +76,6 @@ func printUsage() {
 	fmt.Println("  switch <path>     Switch to a worktree (output cd command)")
 	fmt.Println("  remove <path>     Remove a worktree")
 	fmt.Println("  prune             Clean up non-existent worktrees")
+	fmt.Println("  exec <name> -- <cmd> [args...]   Run a command in a phantom (--all, --jobs, --continue-on-error)")
 	fmt.Println("  help              Show this help message")
 }
\ No newline at end of file
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/shuymn/phantom/gitutil"
+)
+
+func executePrune(args []string) error {
+	flags := flag.NewFlagSet("prune", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	git := newRepoGit()
+
+	output, err := git.WorktreePrune()
+	if err != nil {
+		return fmt.Errorf("failed to prune worktrees: %w", err)
+	}
+	fmt.Print(output)
+
+	commonDir, err := git.RevParseGitCommonDir()
+	if err != nil {
+		return fmt.Errorf("failed to get git common directory: %w", err)
+	}
+
+	return pruneOrphanedPhantomDirs(git, filepath.Join(commonDir, "phantom"))
+}
+
+// pruneOrphanedPhantomDirs removes directories under phantomRoot that no
+// longer correspond to a registered git worktree (e.g. left behind after the
+// worktree metadata itself was deleted by hand). A phantom name may itself
+// contain a "/" (e.g. "feature/foo"), so registered worktrees can be nested
+// several levels deep under phantomRoot; any directory that is neither a
+// registered worktree nor an ancestor of one is orphaned and removed along
+// with its contents.
+func pruneOrphanedPhantomDirs(git *gitutil.Git, phantomRoot string) error {
+	if _, err := os.Stat(phantomRoot); os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", phantomRoot, err)
+	}
+
+	worktrees, err := git.WorktreeList()
+	if err != nil {
+		return fmt.Errorf("failed to list worktrees: %w", err)
+	}
+
+	registered := make(map[string]bool, len(worktrees))
+	keep := make(map[string]bool)
+	for _, wt := range worktrees {
+		path := filepath.Clean(wt.Path)
+		if !isUnder(phantomRoot, path) {
+			continue
+		}
+		registered[path] = true
+		for dir := filepath.Dir(path); dir != phantomRoot; dir = filepath.Dir(dir) {
+			keep[dir] = true
+		}
+	}
+
+	return pruneOrphanedDir(phantomRoot, registered, keep)
+}
+
+// pruneOrphanedDir recursively removes directories under dir that are
+// neither a registered worktree path nor an ancestor of one.
+func pruneOrphanedDir(dir string, registered, keep map[string]bool) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		switch {
+		case registered[path]:
+			// The worktree itself; its contents belong to it, not to phantom's
+			// own bookkeeping.
+		case keep[path]:
+			if err := pruneOrphanedDir(path, registered, keep); err != nil {
+				return err
+			}
+		default:
+			fmt.Printf("Pruning orphaned phantom directory: %s\n", path)
+			if err := os.RemoveAll(path); err != nil {
+				return fmt.Errorf("failed to remove orphaned directory %s: %w", path, err)
+			}
+		}
+	}
+	return nil
+}
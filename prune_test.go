@@ -0,0 +1,84 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExecutePruneRemovesOrphanedDirectory(t *testing.T) {
+	tempDir := setupTestRepo(t)
+
+	if err := executeCreate("feature-a"); err != nil {
+		t.Fatalf("Failed to create phantom: %v", err)
+	}
+
+	orphanPath := filepath.Join(tempDir, ".git", "phantom", "stale-leftover")
+	if err := os.MkdirAll(orphanPath, 0755); err != nil {
+		t.Fatalf("Failed to create orphaned directory: %v", err)
+	}
+
+	if err := executePrune(nil); err != nil {
+		t.Fatalf("executePrune returned error: %v", err)
+	}
+
+	if _, err := os.Stat(orphanPath); !os.IsNotExist(err) {
+		t.Errorf("Expected orphaned directory to be removed, stat err: %v", err)
+	}
+
+	phantomPath := filepath.Join(tempDir, ".git", "phantom", "feature-a")
+	if _, err := os.Stat(phantomPath); err != nil {
+		t.Errorf("Expected registered phantom to survive prune: %v", err)
+	}
+}
+
+func TestExecutePrunePreservesNestedPhantomName(t *testing.T) {
+	tempDir := setupTestRepo(t)
+
+	if err := executeCreate("feature/foo"); err != nil {
+		t.Fatalf("Failed to create phantom: %v", err)
+	}
+
+	if err := executePrune(nil); err != nil {
+		t.Fatalf("executePrune returned error: %v", err)
+	}
+
+	phantomPath := filepath.Join(tempDir, ".git", "phantom", "feature", "foo")
+	if _, err := os.Stat(phantomPath); err != nil {
+		t.Errorf("Expected registered phantom with a nested name to survive prune: %v", err)
+	}
+}
+
+func TestExecutePruneRemovesOrphanedNestedDirectory(t *testing.T) {
+	tempDir := setupTestRepo(t)
+
+	if err := executeCreate("feature/foo"); err != nil {
+		t.Fatalf("Failed to create phantom: %v", err)
+	}
+
+	orphanPath := filepath.Join(tempDir, ".git", "phantom", "feature", "stale")
+	if err := os.MkdirAll(orphanPath, 0755); err != nil {
+		t.Fatalf("Failed to create orphaned directory: %v", err)
+	}
+
+	if err := executePrune(nil); err != nil {
+		t.Fatalf("executePrune returned error: %v", err)
+	}
+
+	if _, err := os.Stat(orphanPath); !os.IsNotExist(err) {
+		t.Errorf("Expected orphaned nested directory to be removed, stat err: %v", err)
+	}
+
+	phantomPath := filepath.Join(tempDir, ".git", "phantom", "feature", "foo")
+	if _, err := os.Stat(phantomPath); err != nil {
+		t.Errorf("Expected registered phantom with a nested name to survive prune: %v", err)
+	}
+}
+
+func TestExecutePruneNoPhantomDir(t *testing.T) {
+	setupTestRepo(t)
+
+	if err := executePrune(nil); err != nil {
+		t.Fatalf("executePrune returned error when no phantom directory exists: %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/shuymn/phantom/gitutil"
+)
+
+func executeRemove(args []string) error {
+	flags := flag.NewFlagSet("remove", flag.ContinueOnError)
+	force := flags.Bool("force", false, "remove even if the worktree is dirty or has unpushed commits")
+	withBranch := flags.Bool("with-branch", false, "also delete the phantom's branch")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	rest := flags.Args()
+	if len(rest) < 1 {
+		return fmt.Errorf("remove requires a phantom name")
+	}
+	name := rest[0]
+
+	git := newRepoGit()
+	repoRoot, err := git.RevParseShowToplevel()
+	if err != nil {
+		return fmt.Errorf("failed to get repository root: %w", err)
+	}
+	commonDir, err := git.RevParseGitCommonDir()
+	if err != nil {
+		return fmt.Errorf("failed to get git common directory: %w", err)
+	}
+	path := filepath.Join(commonDir, "phantom", name)
+
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("phantom %q does not exist", name)
+	}
+	if repoRoot == path {
+		return fmt.Errorf("cannot remove %q: it is the current worktree", name)
+	}
+
+	phantomGit := git.WithDir(path)
+	branch, branchErr := phantomGit.CurrentBranch()
+
+	if !*force {
+		if dirty, _ := phantomGit.IsDirty(); dirty {
+			return fmt.Errorf("phantom %q has uncommitted changes; use --force to remove anyway", name)
+		}
+		if branchErr == nil {
+			unpushed, err := hasUnpushedCommits(git.WithDir(repoRoot), phantomGit, branch)
+			if err != nil {
+				return fmt.Errorf("failed to check phantom %q for unpushed commits: %w", name, err)
+			}
+			if unpushed {
+				return fmt.Errorf("phantom %q has unpushed commits; use --force to remove anyway", name)
+			}
+		}
+	}
+
+	if err := git.WorktreeRemove(path, *force); err != nil {
+		return fmt.Errorf("failed to remove worktree: %w", err)
+	}
+
+	if *withBranch {
+		if branchErr != nil {
+			return fmt.Errorf("failed to determine branch for phantom %q: %w", name, branchErr)
+		}
+		if err := removeBranch(git.WithDir(repoRoot), branch, *force); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Removed phantom '%s'\n", name)
+	return nil
+}
+
+// removeBranch deletes branch using git, refusing to do so unless it is
+// merged into its base branch or force is set.
+func removeBranch(git *gitutil.Git, branch string, force bool) error {
+	base, err := resolveBaseBranch(git)
+	if err != nil {
+		return fmt.Errorf("failed to resolve base branch: %w", err)
+	}
+
+	if !force {
+		merged, err := git.MergeBaseIsAncestor(branch, base)
+		if err != nil {
+			return fmt.Errorf("failed to check whether %q is merged into %q: %w", branch, base, err)
+		}
+		if !merged {
+			return fmt.Errorf("branch %q is not fully merged into %q; use --force to delete anyway", branch, base)
+		}
+	}
+
+	if err := git.BranchDelete(branch, force); err != nil {
+		return fmt.Errorf("failed to delete branch %q: %w", branch, err)
+	}
+	return nil
+}
+
+// resolveBaseBranch returns the branch that phantom branches should be
+// considered merged into before deletion: the repo's configured baseBranch,
+// or the branch currently checked out in git's working directory.
+func resolveBaseBranch(git *gitutil.Git) (string, error) {
+	cfg, err := loadConfig(git.Dir())
+	if err == nil && cfg.BaseBranch != "" {
+		return cfg.BaseBranch, nil
+	}
+	return git.CurrentBranch()
+}
+
+// hasUnpushedCommits reports whether branch, checked out in phantomGit's
+// working directory, has commits not present on its upstream. Phantom
+// branches have no upstream configured by default, so in that case it falls
+// back to comparing against the resolved base branch, the same way
+// removeBranch does. Any other failure from git is propagated rather than
+// silently treated as "no unpushed commits".
+func hasUnpushedCommits(repoGit, phantomGit *gitutil.Git, branch string) (bool, error) {
+	ahead, _, err := phantomGit.AheadBehind(branch)
+	if err == nil {
+		return ahead > 0, nil
+	}
+	if !isNoUpstreamError(err) {
+		return false, err
+	}
+
+	base, err := resolveBaseBranch(repoGit)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve base branch: %w", err)
+	}
+	merged, err := phantomGit.MergeBaseIsAncestor(branch, base)
+	if err != nil {
+		return false, err
+	}
+	return !merged, nil
+}
+
+// isNoUpstreamError reports whether err is git's "no upstream configured for
+// branch" failure, as opposed to some other, unexpected git failure.
+func isNoUpstreamError(err error) bool {
+	var gitErr *gitutil.GitError
+	if !errors.As(err, &gitErr) {
+		return false
+	}
+	return strings.Contains(gitErr.Stderr, "no upstream configured")
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestExecuteRemoveRefusesDirtyWorktree(t *testing.T) {
+	tempDir := setupTestRepo(t)
+
+	if err := executeCreate("feature-a"); err != nil {
+		t.Fatalf("Failed to create phantom: %v", err)
+	}
+	phantomPath := filepath.Join(tempDir, ".git", "phantom", "feature-a")
+	if err := os.WriteFile(filepath.Join(phantomPath, "scratch.txt"), []byte("wip"), 0644); err != nil {
+		t.Fatalf("Failed to dirty worktree: %v", err)
+	}
+
+	if err := executeRemove([]string{"feature-a"}); err == nil {
+		t.Errorf("Expected removal of dirty worktree to be refused")
+	}
+	if _, err := os.Stat(phantomPath); err != nil {
+		t.Errorf("Expected worktree to still exist after refused removal: %v", err)
+	}
+
+	if err := executeRemove([]string{"--force", "feature-a"}); err != nil {
+		t.Fatalf("executeRemove with --force returned error: %v", err)
+	}
+	if _, err := os.Stat(phantomPath); !os.IsNotExist(err) {
+		t.Errorf("Expected worktree to be removed, stat err: %v", err)
+	}
+}
+
+func TestExecuteRemoveRefusesCurrentWorktree(t *testing.T) {
+	tempDir := setupTestRepo(t)
+
+	if err := executeCreate("feature-a"); err != nil {
+		t.Fatalf("Failed to create phantom: %v", err)
+	}
+	phantomPath := filepath.Join(tempDir, ".git", "phantom", "feature-a")
+
+	if err := os.Chdir(phantomPath); err != nil {
+		t.Fatalf("Failed to chdir into phantom: %v", err)
+	}
+
+	if err := executeRemove([]string{"feature-a"}); err == nil {
+		t.Errorf("Expected removal of the current worktree to be refused")
+	}
+}
+
+func TestExecuteRemoveWithBranch(t *testing.T) {
+	setupTestRepo(t)
+
+	if err := executeCreate("feature-a"); err != nil {
+		t.Fatalf("Failed to create phantom: %v", err)
+	}
+
+	if err := executeRemove([]string{"--with-branch", "feature-a"}); err != nil {
+		t.Fatalf("executeRemove with --with-branch returned error: %v", err)
+	}
+
+	output, err := exec.Command("git", "branch", "--list", "feature-a").Output()
+	if err != nil {
+		t.Fatalf("Failed to list branches: %v", err)
+	}
+	if len(output) != 0 {
+		t.Errorf("Expected branch feature-a to be deleted, got %q", output)
+	}
+}
+
+func TestExecuteRemoveRefusesUnpushedCommits(t *testing.T) {
+	tempDir := setupTestRepo(t)
+
+	if err := executeCreate("feature-a"); err != nil {
+		t.Fatalf("Failed to create phantom: %v", err)
+	}
+	phantomPath := filepath.Join(tempDir, ".git", "phantom", "feature-a")
+
+	// feature-a has no upstream configured (as phantom branches don't by
+	// default), so this commit is only detectable by comparing against the
+	// resolved base branch.
+	if err := os.WriteFile(filepath.Join(phantomPath, "new.txt"), []byte("wip"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	commitAll(t, phantomPath, "unpushed change")
+
+	if err := executeRemove([]string{"feature-a"}); err == nil {
+		t.Errorf("Expected removal of phantom with unpushed commits to be refused")
+	}
+	if _, err := os.Stat(phantomPath); err != nil {
+		t.Errorf("Expected worktree to still exist after refused removal: %v", err)
+	}
+
+	if err := executeRemove([]string{"--force", "feature-a"}); err != nil {
+		t.Fatalf("executeRemove with --force returned error: %v", err)
+	}
+	if _, err := os.Stat(phantomPath); !os.IsNotExist(err) {
+		t.Errorf("Expected worktree to be removed, stat err: %v", err)
+	}
+}
+
+// commitAll stages and commits every change under dir.
+func commitAll(t *testing.T, dir, message string) {
+	t.Helper()
+
+	add := exec.Command("git", "add", ".")
+	add.Dir = dir
+	if out, err := add.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to stage changes: %v\n%s", err, out)
+	}
+
+	commit := exec.Command("git", "commit", "-m", message)
+	commit.Dir = dir
+	commit.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	if out, err := commit.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to commit: %v\n%s", err, out)
+	}
+}
+
+func TestExecuteRemoveUnknownPhantom(t *testing.T) {
+	setupTestRepo(t)
+
+	if err := executeRemove([]string{"does-not-exist"}); err == nil {
+		t.Errorf("Expected error for unknown phantom, got none")
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shuymn/phantom/gitutil"
+)
+
+const defaultSelector = "fzf"
+
+func executeSwitch(args []string) error {
+	flags := flag.NewFlagSet("switch", flag.ContinueOnError)
+	printShellInit := flags.String("print-shell-init", "", "print a shell wrapper function for bash, zsh, or fish")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *printShellInit != "" {
+		return printShellInitScript(*printShellInit)
+	}
+
+	name := ""
+	if rest := flags.Args(); len(rest) > 0 {
+		name = rest[0]
+	}
+
+	git := newRepoGit()
+	commonDir, err := git.RevParseGitCommonDir()
+	if err != nil {
+		return fmt.Errorf("failed to get git common directory: %w", err)
+	}
+	phantomRoot := filepath.Join(commonDir, "phantom")
+
+	if name == "" {
+		name, err = selectPhantom(git, phantomRoot)
+		if err != nil {
+			return err
+		}
+	}
+
+	path := filepath.Join(phantomRoot, name)
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		return fmt.Errorf("phantom %q does not exist", name)
+	}
+
+	fmt.Printf("cd %s\n", shellQuote(path))
+	return nil
+}
+
+// selectPhantom prompts the user to choose a phantom, via $PHANTOM_SELECTOR
+// (fzf by default) when stdin/stdout are a TTY, falling back to a plain
+// numeric prompt if the selector binary isn't available.
+func selectPhantom(git *gitutil.Git, phantomRoot string) (string, error) {
+	names, err := listPhantomNames(git, phantomRoot)
+	if err != nil {
+		return "", err
+	}
+	if len(names) == 0 {
+		return "", errors.New("no phantom worktrees found")
+	}
+
+	if !isTerminal(os.Stdin) || !isTerminal(os.Stdout) {
+		return "", errors.New("no phantom name given and stdin/stdout is not a terminal for interactive selection")
+	}
+
+	selector := os.Getenv("PHANTOM_SELECTOR")
+	if selector == "" {
+		selector = defaultSelector
+	}
+
+	if _, err := exec.LookPath(selector); err != nil {
+		return promptNumeric(names)
+	}
+	return runSelector(selector, names)
+}
+
+func listPhantomNames(git *gitutil.Git, phantomRoot string) ([]string, error) {
+	worktrees, err := git.WorktreeList()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list worktrees: %w", err)
+	}
+
+	var names []string
+	for _, wt := range worktrees {
+		if !isUnder(phantomRoot, wt.Path) {
+			continue
+		}
+		names = append(names, strings.TrimPrefix(wt.Path, phantomRoot+string(filepath.Separator)))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func runSelector(selector string, names []string) (string, error) {
+	cmd := exec.Command(selector)
+	cmd.Stdin = strings.NewReader(strings.Join(names, "\n") + "\n")
+	cmd.Stderr = os.Stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("selector %q failed: %w", selector, err)
+	}
+
+	choice := strings.TrimSpace(string(output))
+	if choice == "" {
+		return "", errors.New("no phantom selected")
+	}
+	return choice, nil
+}
+
+func promptNumeric(names []string) (string, error) {
+	fmt.Fprintln(os.Stderr, "Select a phantom:")
+	for i, name := range names {
+		fmt.Fprintf(os.Stderr, "  %d) %s\n", i+1, name)
+	}
+	fmt.Fprint(os.Stderr, "Enter number: ")
+
+	reader := bufio.NewReader(os.Stdin)
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("failed to read selection: %w", err)
+	}
+
+	choice, err := strconv.Atoi(strings.TrimSpace(line))
+	if err != nil || choice < 1 || choice > len(names) {
+		return "", fmt.Errorf("invalid selection %q", strings.TrimSpace(line))
+	}
+	return names[choice-1], nil
+}
+
+func printShellInitScript(shell string) error {
+	// Users type `git phantom switch`, which the shell resolves to the real
+	// git binary (it has no function to intercept it) before phantom ever
+	// gets a chance to `cd`. So the wrapper must shadow `git` itself, not
+	// `git-phantom`, and only special-case the `phantom switch` subcommand.
+	var script string
+	switch shell {
+	case "bash", "zsh":
+		script = `git() {
+  if [ "$1" = "phantom" ] && [ "$2" = "switch" ]; then
+    eval "$(command git phantom switch "${@:3}")"
+  else
+    command git "$@"
+  fi
+}
+`
+	case "fish":
+		script = `function git
+    if test "$argv[1]" = "phantom"; and test "$argv[2]" = "switch"
+        eval (command git phantom switch $argv[3..-1])
+    else
+        command git $argv
+    end
+end
+`
+	default:
+		return fmt.Errorf("unsupported shell %q: must be bash, zsh, or fish", shell)
+	}
+
+	fmt.Print(script)
+	return nil
+}
+
+// isTerminal reports whether f is connected to a terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// shellQuote wraps s in single quotes for safe use in a POSIX shell `eval`,
+// escaping any embedded single quotes.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestExecuteSwitchEvalPath(t *testing.T) {
+	tempDir := setupTestRepo(t)
+
+	if err := executeCreate("feature-a"); err != nil {
+		t.Fatalf("Failed to create phantom: %v", err)
+	}
+
+	stdout, err := captureStdout(t, func() error {
+		return executeSwitch([]string{"feature-a"})
+	})
+	if err != nil {
+		t.Fatalf("executeSwitch returned error: %v", err)
+	}
+
+	wantPath := filepath.Join(tempDir, ".git", "phantom", "feature-a")
+	want := fmt.Sprintf("cd %s\n", shellQuote(wantPath))
+	if stdout != want {
+		t.Errorf("Expected stdout %q, got %q", want, stdout)
+	}
+}
+
+func TestExecuteSwitchFromInsidePhantom(t *testing.T) {
+	tempDir := setupTestRepo(t)
+
+	if err := executeCreate("feature-a"); err != nil {
+		t.Fatalf("Failed to create phantom: %v", err)
+	}
+	if err := executeCreate("feature-b"); err != nil {
+		t.Fatalf("Failed to create phantom: %v", err)
+	}
+
+	if err := os.Chdir(filepath.Join(tempDir, ".git", "phantom", "feature-a")); err != nil {
+		t.Fatalf("Failed to chdir into phantom: %v", err)
+	}
+
+	stdout, err := captureStdout(t, func() error {
+		return executeSwitch([]string{"feature-b"})
+	})
+	if err != nil {
+		t.Fatalf("executeSwitch returned error when run from inside a phantom: %v", err)
+	}
+
+	wantPath := filepath.Join(tempDir, ".git", "phantom", "feature-b")
+	want := fmt.Sprintf("cd %s\n", shellQuote(wantPath))
+	if stdout != want {
+		t.Errorf("Expected stdout %q, got %q", want, stdout)
+	}
+}
+
+func TestExecuteSwitchUnknownPhantom(t *testing.T) {
+	setupTestRepo(t)
+
+	if err := executeSwitch([]string{"does-not-exist"}); err == nil {
+		t.Errorf("Expected error for unknown phantom, got none")
+	}
+}
+
+// installFakeSelector writes an executable script named `name` to a
+// temporary directory and prepends that directory to PATH for the duration
+// of the test.
+func installFakeSelector(t *testing.T, name, script string) {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("fake selector script requires a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0755); err != nil {
+		t.Fatalf("Failed to write fake selector: %v", err)
+	}
+
+	originalPath := os.Getenv("PATH")
+	os.Setenv("PATH", dir+string(os.PathListSeparator)+originalPath)
+	t.Cleanup(func() { os.Setenv("PATH", originalPath) })
+}
+
+func TestRunSelectorWithFakeSelector(t *testing.T) {
+	installFakeSelector(t, "fake-selector", "grep feature-b\n")
+
+	names := []string{"feature-a", "feature-b", "feature-c"}
+	choice, err := runSelector("fake-selector", names)
+	if err != nil {
+		t.Fatalf("runSelector returned error: %v", err)
+	}
+	if choice != "feature-b" {
+		t.Errorf("Expected choice %q, got %q", "feature-b", choice)
+	}
+}
+
+func TestRunSelectorNoSelection(t *testing.T) {
+	installFakeSelector(t, "fake-selector", "grep does-not-match\n")
+
+	_, err := runSelector("fake-selector", []string{"feature-a"})
+	if err == nil {
+		t.Errorf("Expected error when selector outputs nothing, got none")
+	}
+}
+
+func TestPrintShellInitScript(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		t.Run(shell, func(t *testing.T) {
+			stdout, err := captureStdout(t, func() error {
+				return printShellInitScript(shell)
+			})
+			if err != nil {
+				t.Fatalf("printShellInitScript(%q) returned error: %v", shell, err)
+			}
+			// The wrapper must shadow `git` itself (not `git-phantom`): the
+			// shell resolves `git phantom switch` to the real git binary
+			// before any `git-phantom` function would get a chance to run.
+			if !strings.Contains(stdout, "phantom") || !strings.Contains(stdout, "switch") {
+				t.Errorf("Expected shell init script to dispatch on phantom switch, got: %s", stdout)
+			}
+			if strings.Contains(stdout, "git-phantom()") || strings.Contains(stdout, "function git-phantom") {
+				t.Errorf("Expected shell init script to define git, not git-phantom, got: %s", stdout)
+			}
+		})
+	}
+}
+
+func TestPrintShellInitScriptUnsupportedShell(t *testing.T) {
+	if err := printShellInitScript("powershell"); err == nil {
+		t.Errorf("Expected error for unsupported shell, got none")
+	}
+}
+
+// TestGitWrapperInterceptsPhantomSwitch sources the generated bash wrapper
+// and confirms `git phantom switch <name>` actually changes the shell's
+// working directory, rather than just printing `cd ...` with no effect.
+func TestGitWrapperInterceptsPhantomSwitch(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell wrapper test requires a POSIX shell")
+	}
+
+	fakeBinDir := t.TempDir()
+	targetDir := t.TempDir()
+
+	// Stand in for the real phantom binary: `git phantom switch <name>` is
+	// dispatched by git to a `git-phantom` executable on PATH.
+	fakeGitPhantom := "#!/bin/sh\n" +
+		`if [ "$1" = "switch" ]; then` + "\n" +
+		`  echo "cd ` + shellQuote(targetDir) + `"` + "\n" +
+		"fi\n"
+	if err := os.WriteFile(filepath.Join(fakeBinDir, "git-phantom"), []byte(fakeGitPhantom), 0755); err != nil {
+		t.Fatalf("Failed to write fake git-phantom: %v", err)
+	}
+
+	wrapper, err := captureStdout(t, func() error {
+		return printShellInitScript("bash")
+	})
+	if err != nil {
+		t.Fatalf("printShellInitScript returned error: %v", err)
+	}
+
+	script := wrapper + "\ngit phantom switch my-feature\npwd\n"
+	cmd := exec.Command("bash", "-c", script)
+	cmd.Env = append(os.Environ(), "PATH="+fakeBinDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("wrapper script failed: %v\noutput: %s", err, output)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	gotDir := lines[len(lines)-1]
+
+	wantDir, err := filepath.EvalSymlinks(targetDir)
+	if err != nil {
+		t.Fatalf("Failed to resolve target dir: %v", err)
+	}
+	gotDirResolved, err := filepath.EvalSymlinks(gotDir)
+	if err != nil {
+		t.Fatalf("Failed to resolve pwd output %q: %v", gotDir, err)
+	}
+	if gotDirResolved != wantDir {
+		t.Errorf("Expected git phantom switch to cd into %q, got %q (full output: %s)", wantDir, gotDir, output)
+	}
+}